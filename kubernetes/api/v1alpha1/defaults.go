@@ -0,0 +1,36 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package v1alpha1
+
+// SetDefaults_ElasticJob fills in default values for fields that users left
+// unset, so the rest of the operator never has to special-case the zero
+// value of SuccessPolicy/FailurePolicy, and persists the MinReplicas/
+// MaxReplicas the controller would otherwise compute implicitly on every
+// reconcile (see minReplicasFor/maxReplicasFor/computeDesiredReplicas in
+// the controllers package), so a client reading the object back sees the
+// range it's actually running under.
+func SetDefaults_ElasticJob(job *ElasticJob) {
+	if job.Spec.SuccessPolicy == "" {
+		job.Spec.SuccessPolicy = SuccessPolicyDefault
+	}
+
+	if job.Spec.FailurePolicy == "" {
+		job.Spec.FailurePolicy = FailurePolicyDefault
+	}
+
+	desiredReplicas := desiredReplicasFor(&job.Spec)
+	if job.Spec.MinReplicas == nil {
+		minReplicas := desiredReplicas
+		job.Spec.MinReplicas = &minReplicas
+	}
+	if job.Spec.MaxReplicas == nil {
+		maxReplicas := desiredReplicas
+		job.Spec.MaxReplicas = &maxReplicas
+	}
+}
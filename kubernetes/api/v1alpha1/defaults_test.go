@@ -0,0 +1,50 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestSetDefaultsElasticJobFillsInMinMaxReplicas(t *testing.T) {
+	RegisterFailHandler(Fail)
+
+	spec := validJobSpec()
+	spec.ReplicaSpecs[ElasticReplicaTypeWorker].Replicas = int32Ptr(4)
+	spec.MinReplicas = nil
+	spec.MaxReplicas = nil
+	job := &ElasticJob{Spec: spec}
+
+	SetDefaults_ElasticJob(job)
+
+	Expect(job.Spec.MinReplicas).NotTo(BeNil())
+	Expect(*job.Spec.MinReplicas).To(Equal(int32(4)))
+	Expect(job.Spec.MaxReplicas).NotTo(BeNil())
+	Expect(*job.Spec.MaxReplicas).To(Equal(int32(4)))
+
+	// Defaulting MinReplicas/MaxReplicas independently must not alias the
+	// same pointer.
+	*job.Spec.MinReplicas = 1
+	Expect(*job.Spec.MaxReplicas).To(Equal(int32(4)))
+}
+
+func TestSetDefaultsElasticJobLeavesExplicitMinMaxReplicas(t *testing.T) {
+	RegisterFailHandler(Fail)
+
+	spec := validJobSpec()
+	job := &ElasticJob{Spec: spec}
+
+	SetDefaults_ElasticJob(job)
+
+	Expect(*job.Spec.MinReplicas).To(Equal(int32(1)))
+	Expect(*job.Spec.MaxReplicas).To(Equal(int32(4)))
+}
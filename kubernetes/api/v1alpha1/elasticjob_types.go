@@ -0,0 +1,231 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package v1alpha1
+
+import (
+	commonv1 "github.com/kubeflow/common/pkg/apis/common/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SuccessPolicy determines how many successful replicas are required before
+// an ElasticJob is considered Succeeded. Elastic jobs don't fit
+// kubeflow-common's default "all replicas must succeed" semantics because the
+// worker count legitimately fluctuates between MinReplicas and MaxReplicas.
+type SuccessPolicy string
+
+const (
+	// SuccessPolicyDefault preserves kubeflow-common's default behavior: the
+	// job succeeds only once every replica it created has succeeded.
+	SuccessPolicyDefault SuccessPolicy = ""
+	// SuccessPolicyAllWorkers is an explicit alias of SuccessPolicyDefault for
+	// specs that want to be unambiguous about the all-replicas semantics.
+	SuccessPolicyAllWorkers SuccessPolicy = "AllWorkers"
+	// SuccessPolicyAny marks the job Succeeded once at least MinReplicas
+	// workers have completed successfully, since an etcd-rendezvous elastic
+	// run can legitimately finish with fewer pods than desiredReplicas.
+	SuccessPolicyAny SuccessPolicy = "Any"
+)
+
+// FailurePolicy determines how an ElasticJob reacts to pod failures while
+// rescaling between MinReplicas and MaxReplicas.
+type FailurePolicy string
+
+const (
+	// FailurePolicyDefault fails the job as soon as a single pod fails.
+	FailurePolicyDefault FailurePolicy = ""
+	// FailurePolicyFailFast is an explicit alias of FailurePolicyDefault.
+	FailurePolicyFailFast FailurePolicy = "FailFast"
+	// FailurePolicyTolerateMaxReplicas tolerates up to
+	// MaxReplicas-MinReplicas pod failures before the job is marked Failed,
+	// since elastic jobs are expected to shed workers under churn.
+	FailurePolicyTolerateMaxReplicas FailurePolicy = "TolerateMaxReplicas"
+)
+
+// ElasticReplicaTypeWorker is the ReplicaType key under which
+// ElasticJobSpec.ReplicaSpecs carries the worker pod template.
+const ElasticReplicaTypeWorker commonv1.ReplicaType = "Worker"
+
+// GangScheduler identifies which gang-scheduling integration the
+// controller should drive for an ElasticJob.
+type GangScheduler string
+
+const (
+	// GangSchedulerNone disables gang scheduling (the default).
+	GangSchedulerNone GangScheduler = ""
+	// GangSchedulerVolcano gang-schedules pods via a volcano.sh PodGroup.
+	GangSchedulerVolcano GangScheduler = "volcano"
+)
+
+// SchedulingPolicy configures optional gang-scheduling for an ElasticJob.
+type SchedulingPolicy struct {
+	// Scheduler selects the gang-scheduling integration to use. Leave unset
+	// to disable gang scheduling entirely.
+	// +optional
+	Scheduler GangScheduler `json:"scheduler,omitempty"`
+
+	// Queue is the volcano queue the job's PodGroup is submitted to.
+	// +optional
+	Queue string `json:"queue,omitempty"`
+
+	// PriorityClassName is propagated to the PodGroup so volcano can order
+	// gang admission across jobs.
+	// +optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+}
+
+// LaunchType selects which PyTorch distributed launcher flag style
+// SetClusterSpecForPod emits when injecting rendezvous args.
+type LaunchType string
+
+const (
+	// LaunchTypeUnspecified lets the controller detect the launch type from
+	// the container's args (see detectLaunchType's launcher token scan).
+	LaunchTypeUnspecified LaunchType = ""
+	// LaunchTypeLaunch targets the legacy
+	// `python -m torchelastic.distributed.launch` entrypoint.
+	LaunchTypeLaunch LaunchType = "Launch"
+	// LaunchTypeRun targets `python -m torch.distributed.run`.
+	LaunchTypeRun LaunchType = "Run"
+	// LaunchTypeTorchrun targets the `torchrun` console script, which uses
+	// hyphenated `--rdzv-*` flags instead of the older `--rdzv_*` style.
+	LaunchTypeTorchrun LaunchType = "Torchrun"
+)
+
+// RecognizedLaunchers lists the PyTorch distributed launcher entrypoints
+// the controller knows how to inject rendezvous args for, and the
+// LaunchType each one implies. Shared by InsertTorchArgs/detectLaunchType
+// and the validating webhook, which accepts a first container that
+// references one of these or names a bare script entrypoint, and rejects
+// one with no args at all.
+var RecognizedLaunchers = map[string]LaunchType{
+	"torchelastic.distributed.launch": LaunchTypeLaunch,
+	"torch.distributed.run":           LaunchTypeRun,
+	"torchrun":                        LaunchTypeTorchrun,
+}
+
+// RendezvousBackend identifies which rendezvous mechanism the worker pods
+// use to discover each other across elastic rescaling events.
+type RendezvousBackend string
+
+const (
+	// RendezvousBackendEtcd preserves the original hard-coded etcd behavior.
+	RendezvousBackendEtcd RendezvousBackend = "etcd"
+	// RendezvousBackendEtcdV2 uses torchelastic's etcd-v2 rendezvous handler.
+	RendezvousBackendEtcdV2 RendezvousBackend = "etcd-v2"
+	// RendezvousBackendC10d uses the TCPStore-based c10d backend built into
+	// recent PyTorch, rendezvousing through a stable rank-0 pod instead of
+	// an external store.
+	RendezvousBackendC10d RendezvousBackend = "c10d"
+	// RendezvousBackendRedis uses a redis-backed rendezvous store, either
+	// an external one (Endpoint) or one the controller provisions
+	// (Provision).
+	RendezvousBackendRedis RendezvousBackend = "redis"
+)
+
+// RendezvousSpec configures the rendezvous backend workers use to discover
+// each other across elastic rescaling events.
+type RendezvousSpec struct {
+	// Backend selects the rendezvous mechanism. Defaults to
+	// RendezvousBackendEtcd, preserving the original hard-coded behavior.
+	// +optional
+	Backend RendezvousBackend `json:"backend,omitempty"`
+
+	// Endpoint is the external rendezvous store address. Required for
+	// etcd/etcd-v2, and for redis unless Provision is set. Ignored for
+	// c10d, which derives its endpoint from the rank-0 pod.
+	// +optional
+	Endpoint string `json:"endpoint,omitempty"`
+
+	// Provision requests that the controller provision a small redis
+	// Deployment for rendezvous instead of using an external one. Only
+	// meaningful when Backend is RendezvousBackendRedis.
+	// +optional
+	Provision bool `json:"provision,omitempty"`
+}
+
+// ElasticJobSpec defines the desired state of ElasticJob.
+type ElasticJobSpec struct {
+	// RdzvEndpoint is the rendezvous endpoint passed to torchelastic.
+	//
+	// Deprecated: use Rendezvous.Endpoint instead. Still honored as the
+	// etcd endpoint when Rendezvous is unset.
+	// +optional
+	RdzvEndpoint string `json:"rdzvEndpoint,omitempty"`
+
+	// Rendezvous configures the rendezvous backend (etcd, c10d, redis) used
+	// for worker discovery. Defaults to etcd using RdzvEndpoint when unset.
+	// +optional
+	Rendezvous *RendezvousSpec `json:"rendezvous,omitempty"`
+
+	// LaunchType selects which launcher flag style to use when injecting
+	// rendezvous args. Leave unset to auto-detect from the container's args.
+	// +optional
+	LaunchType LaunchType `json:"launchType,omitempty"`
+
+	// SchedulingPolicy optionally enables gang scheduling for the job's
+	// worker pods through volcano.
+	// +optional
+	SchedulingPolicy *SchedulingPolicy `json:"schedulingPolicy,omitempty"`
+
+	// MinReplicas is the minimum number of worker replicas the job can run
+	// with. Defaults to the replica count of the worker ReplicaSpec.
+	// +optional
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+
+	// MaxReplicas is the maximum number of worker replicas the job can scale
+	// up to. Defaults to the replica count of the worker ReplicaSpec.
+	// +optional
+	MaxReplicas *int32 `json:"maxReplicas,omitempty"`
+
+	// SuccessPolicy determines how many successful replicas are required
+	// before the job is marked Succeeded.
+	// +optional
+	SuccessPolicy SuccessPolicy `json:"successPolicy,omitempty"`
+
+	// FailurePolicy determines how many pod failures are tolerated before
+	// the job is marked Failed.
+	// +optional
+	FailurePolicy FailurePolicy `json:"failurePolicy,omitempty"`
+
+	// ReplicaSpecs contains maps from ReplicaType to ReplicaSpec that
+	// specify the ElasticJob to be run.
+	ReplicaSpecs map[commonv1.ReplicaType]*commonv1.ReplicaSpec `json:"replicaSpecs"`
+
+	// RunPolicy encapsulates various runtime policies of the job, for
+	// example how to clean up resources and how long the job can stay
+	// active.
+	// +optional
+	RunPolicy commonv1.RunPolicy `json:"runPolicy,omitempty"`
+}
+
+// ElasticJobStatus defines the observed state of ElasticJob.
+type ElasticJobStatus struct {
+	commonv1.JobStatus `json:",inline"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// ElasticJob is the Schema for the elasticjobs API.
+type ElasticJob struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ElasticJobSpec   `json:"spec,omitempty"`
+	Status ElasticJobStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ElasticJobList contains a list of ElasticJob.
+type ElasticJobList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ElasticJob `json:"items"`
+}
@@ -0,0 +1,202 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+var elasticjoblog = logf.Log.WithName("elasticjob-webhook")
+
+// dns1035LabelRegexp mirrors RFC 1035: must start with a letter, contain
+// only lowercase alphanumerics or '-', and end with an alphanumeric. The
+// 63-character limit is checked separately in validateDNS1035Name.
+var dns1035LabelRegexp = regexp.MustCompile(`^[a-z]([-a-z0-9]*[a-z0-9])?$`)
+
+const dns1035LabelMaxLength = 63
+
+// SetupWebhookWithManager registers the defaulting and validating webhooks
+// for ElasticJob.
+func (r *ElasticJob) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-elastic-pytorch-org-v1alpha1-elasticjob,mutating=true,failurePolicy=fail,sideEffects=None,groups=elastic.pytorch.org,resources=elasticjobs,verbs=create;update,versions=v1alpha1,name=melasticjob.kb.io,admissionReviewVersions=v1
+
+var _ webhook.Defaulter = &ElasticJob{}
+
+// Default implements webhook.Defaulter.
+func (r *ElasticJob) Default() {
+	elasticjoblog.Info("default", "name", r.Name)
+	SetDefaults_ElasticJob(r)
+}
+
+// +kubebuilder:webhook:path=/validate-elastic-pytorch-org-v1alpha1-elasticjob,mutating=false,failurePolicy=fail,sideEffects=None,groups=elastic.pytorch.org,resources=elasticjobs,verbs=create;update,versions=v1alpha1,name=velasticjob.kb.io,admissionReviewVersions=v1
+
+var _ webhook.Validator = &ElasticJob{}
+
+// ValidateCreate implements webhook.Validator.
+func (r *ElasticJob) ValidateCreate() error {
+	elasticjoblog.Info("validate create", "name", r.Name)
+	return r.validate()
+}
+
+// ValidateUpdate implements webhook.Validator.
+func (r *ElasticJob) ValidateUpdate(old runtime.Object) error {
+	elasticjoblog.Info("validate update", "name", r.Name)
+	return r.validate()
+}
+
+// ValidateDelete implements webhook.Validator. Deletes are always allowed.
+func (r *ElasticJob) ValidateDelete() error {
+	return nil
+}
+
+func (r *ElasticJob) validate() error {
+	var allErrs field.ErrorList
+
+	if errs := validateDNS1035Name(r.GetName(), &r.Spec, field.NewPath("metadata").Child("name")); len(errs) > 0 {
+		allErrs = append(allErrs, errs...)
+	}
+
+	allErrs = append(allErrs, r.Spec.validate(field.NewPath("spec"))...)
+
+	if len(allErrs) == 0 {
+		return nil
+	}
+
+	return apierrors.NewInvalid(
+		schema.GroupKind{Group: GroupVersion.Group, Kind: "ElasticJob"},
+		r.Name, allErrs)
+}
+
+// validate checks the fields SetClusterSpecForPod/ModifyVolumeMount/
+// InsertTorchArgs rely on silently succeeding, so that misconfiguration
+// surfaces here instead of deep inside pod creation.
+func (s *ElasticJobSpec) validate(fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	desiredReplicas := desiredReplicasFor(s)
+
+	var minReplicas, maxReplicas int32
+	if s.MinReplicas != nil {
+		minReplicas = *s.MinReplicas
+	} else {
+		minReplicas = desiredReplicas
+	}
+	if s.MaxReplicas != nil {
+		maxReplicas = *s.MaxReplicas
+	} else {
+		maxReplicas = desiredReplicas
+	}
+
+	if minReplicas < 1 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("minReplicas"), minReplicas, "must be at least 1"))
+	}
+	if minReplicas > maxReplicas {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("minReplicas"), minReplicas, "must be less than or equal to maxReplicas"))
+	}
+
+	rendezvous := s.Rendezvous
+	if rendezvous == nil {
+		rendezvous = &RendezvousSpec{Backend: RendezvousBackendEtcd, Endpoint: s.RdzvEndpoint}
+	}
+	// An unset Backend defaults to etcd, so it needs an endpoint too.
+	isEtcd := rendezvous.Backend == "" || rendezvous.Backend == RendezvousBackendEtcd || rendezvous.Backend == RendezvousBackendEtcdV2
+	if isEtcd && rendezvous.Endpoint == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("rendezvous", "endpoint"), "required when rendezvous backend is etcd"))
+	}
+
+	if workerSpec, ok := s.ReplicaSpecs[ElasticReplicaTypeWorker]; ok && workerSpec != nil {
+		if len(workerSpec.Template.Spec.Containers) == 0 {
+			allErrs = append(allErrs, field.Required(fldPath.Child("replicaSpecs").Key(string(ElasticReplicaTypeWorker)), "worker template must declare at least one container"))
+		} else if !hasRecognizedLauncher(workerSpec.Template.Spec.Containers[0].Args) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("replicaSpecs").Key(string(ElasticReplicaTypeWorker)),
+				workerSpec.Template.Spec.Containers[0].Args,
+				fmt.Sprintf("args must include a recognized launcher token (%v) or a resolvable entrypoint script", recognizedLauncherTokens())))
+		}
+	}
+
+	return allErrs
+}
+
+// desiredReplicasFor mirrors controllers.computeDesiredReplicas: the worker
+// ReplicaSpec's Replicas count, defaulting to 1 when unset. Unlike
+// computeDesiredReplicas, which returns an error when the worker spec is
+// missing, it also returns 1 in that case. minReplicasFor/maxReplicasFor in that package default
+// MinReplicas/MaxReplicas the same way, so validation has to agree with it
+// here or a spec that passes admission can still resolve to an inverted
+// MinReplicas > MaxReplicas range at runtime.
+func desiredReplicasFor(s *ElasticJobSpec) int32 {
+	workerSpec, ok := s.ReplicaSpecs[ElasticReplicaTypeWorker]
+	if !ok || workerSpec == nil || workerSpec.Replicas == nil {
+		return 1
+	}
+	return *workerSpec.Replicas
+}
+
+func hasRecognizedLauncher(args []string) bool {
+	for _, arg := range args {
+		if _, ok := RecognizedLaunchers[arg]; ok {
+			return true
+		}
+	}
+	// Fall back to accepting a bare script entrypoint (e.g. "train.py"),
+	// which InsertTorchArgs also treats as valid by inserting at arg 0.
+	return len(args) > 0
+}
+
+func recognizedLauncherTokens() []string {
+	tokens := make([]string, 0, len(RecognizedLaunchers))
+	for token := range RecognizedLaunchers {
+		tokens = append(tokens, token)
+	}
+	return tokens
+}
+
+// validateDNS1035Name rejects names that would produce invalid pod/PVC
+// names once SetClusterSpecForPod/ModifyVolumeMount append "-<index>".
+func validateDNS1035Name(name string, spec *ElasticJobSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if len(name) == 0 {
+		return append(allErrs, field.Required(fldPath, "name is required"))
+	}
+
+	// Reserve room for the "-<index>" suffix derived pod/PVC names get,
+	// sized for the largest index, maxReplicas-1.
+	maxReplicas := desiredReplicasFor(spec)
+	if spec.MaxReplicas != nil {
+		maxReplicas = *spec.MaxReplicas
+	}
+	maxLength := dns1035LabelMaxLength - len("-"+strconv.Itoa(int(maxReplicas-1)))
+	if len(name) > maxLength {
+		allErrs = append(allErrs, field.Invalid(fldPath, name,
+			fmt.Sprintf("must be no more than %d characters to leave room for the derived \"-<index>\" pod/PVC suffix", maxLength)))
+	}
+
+	if !dns1035LabelRegexp.MatchString(name) {
+		allErrs = append(allErrs, field.Invalid(fldPath, name,
+			"must consist of lowercase alphanumeric characters or '-', start with a letter (RFC 1035)"))
+	}
+
+	return allErrs
+}
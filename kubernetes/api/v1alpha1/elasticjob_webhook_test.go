@@ -0,0 +1,136 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package v1alpha1
+
+import (
+	"strings"
+	"testing"
+
+	commonv1 "github.com/kubeflow/common/pkg/apis/common/v1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func int32Ptr(i int32) *int32 { return &i }
+
+func validJobSpec() ElasticJobSpec {
+	return ElasticJobSpec{
+		MinReplicas:  int32Ptr(1),
+		MaxReplicas:  int32Ptr(4),
+		RdzvEndpoint: "etcd:2379",
+		ReplicaSpecs: map[commonv1.ReplicaType]*commonv1.ReplicaSpec{
+			ElasticReplicaTypeWorker: {
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{{
+							Args: []string{"python", "-m", "torchelastic.distributed.launch", "train.py"},
+						}},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestValidateCreateAcceptsValidJob(t *testing.T) {
+	RegisterFailHandler(Fail)
+
+	job := &ElasticJob{ObjectMeta: metav1.ObjectMeta{Name: "valid-job"}, Spec: validJobSpec()}
+	Expect(job.ValidateCreate()).NotTo(HaveOccurred())
+}
+
+func TestValidateCreateRejectsInvalidDNSName(t *testing.T) {
+	RegisterFailHandler(Fail)
+
+	job := &ElasticJob{ObjectMeta: metav1.ObjectMeta{Name: "Invalid_Name"}, Spec: validJobSpec()}
+	err := job.ValidateCreate()
+	Expect(err).To(HaveOccurred())
+	Expect(err.Error()).To(ContainSubstring("RFC 1035"))
+}
+
+func TestValidateCreateRejectsNameTooLong(t *testing.T) {
+	RegisterFailHandler(Fail)
+
+	job := &ElasticJob{ObjectMeta: metav1.ObjectMeta{Name: strings.Repeat("a", 62)}, Spec: validJobSpec()}
+	err := job.ValidateCreate()
+	Expect(err).To(HaveOccurred())
+	Expect(err.Error()).To(ContainSubstring("derived"))
+}
+
+func TestValidateCreateRejectsMinGreaterThanMax(t *testing.T) {
+	RegisterFailHandler(Fail)
+
+	spec := validJobSpec()
+	spec.MinReplicas = int32Ptr(4)
+	spec.MaxReplicas = int32Ptr(2)
+	job := &ElasticJob{ObjectMeta: metav1.ObjectMeta{Name: "job"}, Spec: spec}
+	err := job.ValidateCreate()
+	Expect(err).To(HaveOccurred())
+	Expect(err.Error()).To(ContainSubstring("maxReplicas"))
+}
+
+func TestValidateCreateRejectsMaxReplicasBelowDesired(t *testing.T) {
+	RegisterFailHandler(Fail)
+
+	// MinReplicas is left unset, so it should default to the worker
+	// ReplicaSpec's Replicas count (4), matching minReplicasFor at runtime
+	// -- not to 1. With that default, MinReplicas(4) > MaxReplicas(2) and
+	// admission must reject it.
+	spec := validJobSpec()
+	spec.ReplicaSpecs[ElasticReplicaTypeWorker].Replicas = int32Ptr(4)
+	spec.MinReplicas = nil
+	spec.MaxReplicas = int32Ptr(2)
+	job := &ElasticJob{ObjectMeta: metav1.ObjectMeta{Name: "job"}, Spec: spec}
+	err := job.ValidateCreate()
+	Expect(err).To(HaveOccurred())
+	Expect(err.Error()).To(ContainSubstring("maxReplicas"))
+}
+
+func TestValidateCreateRejectsMinReplicasBelowOne(t *testing.T) {
+	RegisterFailHandler(Fail)
+
+	spec := validJobSpec()
+	spec.MinReplicas = int32Ptr(0)
+	job := &ElasticJob{ObjectMeta: metav1.ObjectMeta{Name: "job"}, Spec: spec}
+	Expect(job.ValidateCreate()).To(HaveOccurred())
+}
+
+func TestValidateCreateRejectsEmptyEtcdEndpoint(t *testing.T) {
+	RegisterFailHandler(Fail)
+
+	spec := validJobSpec()
+	spec.RdzvEndpoint = ""
+	job := &ElasticJob{ObjectMeta: metav1.ObjectMeta{Name: "job"}, Spec: spec}
+	err := job.ValidateCreate()
+	Expect(err).To(HaveOccurred())
+	Expect(err.Error()).To(ContainSubstring("rendezvous"))
+}
+
+func TestValidateCreateAllowsEmptyEtcdEndpointForC10d(t *testing.T) {
+	RegisterFailHandler(Fail)
+
+	spec := validJobSpec()
+	spec.RdzvEndpoint = ""
+	spec.Rendezvous = &RendezvousSpec{Backend: RendezvousBackendC10d}
+	job := &ElasticJob{ObjectMeta: metav1.ObjectMeta{Name: "job"}, Spec: spec}
+	Expect(job.ValidateCreate()).NotTo(HaveOccurred())
+}
+
+func TestValidateCreateRejectsUnresolvableEntrypoint(t *testing.T) {
+	RegisterFailHandler(Fail)
+
+	spec := validJobSpec()
+	spec.ReplicaSpecs[ElasticReplicaTypeWorker].Template.Spec.Containers = []corev1.Container{{}}
+	job := &ElasticJob{ObjectMeta: metav1.ObjectMeta{Name: "job"}, Spec: spec}
+	err := job.ValidateCreate()
+	Expect(err).To(HaveOccurred())
+	Expect(err.Error()).To(ContainSubstring("launcher"))
+}
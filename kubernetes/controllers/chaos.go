@@ -0,0 +1,110 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package controllers
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/pytorch/elastic/kubernetes/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// chaosInterval is how often a job with chaos injection enabled gets
+// requeued for another round of RunChaos.
+const chaosInterval = 30 * time.Second
+
+// ChaosLevel controls how aggressively the controller chaos-kills worker
+// pods to exercise etcd-rendezvous re-membership under churn. It is
+// explicitly opt-in and must never be enabled in production.
+type ChaosLevel int
+
+const (
+	// ChaosLevelDisabled turns chaos injection off entirely (the default).
+	ChaosLevelDisabled ChaosLevel = 0
+	// ChaosLevelLow kills one worker per interval.
+	ChaosLevelLow ChaosLevel = 1
+	// ChaosLevelHigh kills up to desiredReplicas-MinReplicas workers per
+	// interval.
+	ChaosLevelHigh ChaosLevel = 2
+)
+
+// ChaosKillLevel is the process-wide chaos level, set from main via
+// --chaos-level. This is a testing/validation aid, not a production
+// feature: leave it at ChaosLevelDisabled unless you're deliberately
+// exercising elastic rescaling.
+var ChaosKillLevel = ChaosLevelDisabled
+
+// selectChaosVictims picks the pods to chaos-kill this interval. It never
+// selects enough pods to drop the running count below MinReplicas.
+func selectChaosVictims(job *v1alpha1.ElasticJob, pods []*corev1.Pod) ([]*corev1.Pod, error) {
+	if ChaosKillLevel == ChaosLevelDisabled || len(pods) == 0 {
+		return nil, nil
+	}
+
+	minReplicas, err := minReplicasFor(job)
+	if err != nil {
+		return nil, err
+	}
+
+	headroom := int32(len(pods)) - minReplicas
+	if headroom <= 0 {
+		return nil, nil
+	}
+
+	killCount := int32(1)
+	if ChaosKillLevel == ChaosLevelHigh {
+		desiredReplicas, err := computeDesiredReplicas(job)
+		if err != nil {
+			return nil, err
+		}
+		if maxKill := desiredReplicas - minReplicas; maxKill > killCount {
+			killCount = maxKill
+		}
+	}
+	if killCount > headroom {
+		killCount = headroom
+	}
+
+	shuffled := make([]*corev1.Pod, len(pods))
+	copy(shuffled, pods)
+	rand.Shuffle(len(shuffled), func(i, j int) { shuffled[i], shuffled[j] = shuffled[j], shuffled[i] })
+
+	return shuffled[:killCount], nil
+}
+
+// RunChaos deletes a random subset of the job's pods to exercise the
+// etcd-rendezvous re-membership logic, validating that the torchelastic
+// launch args produced by InsertTorchArgs actually recover under churn.
+// Gated behind --chaos-level; this is explicitly non-production.
+func (r *ElasticJobReconciler) RunChaos(job *v1alpha1.ElasticJob) error {
+	if ChaosKillLevel == ChaosLevelDisabled {
+		return nil
+	}
+
+	pods, err := r.GetPodsForJob(job)
+	if err != nil {
+		return err
+	}
+
+	victims, err := selectChaosVictims(job, pods)
+	if err != nil {
+		return err
+	}
+
+	for _, pod := range victims {
+		r.jobController.Recorder.Eventf(job, corev1.EventTypeWarning, "ChaosKill",
+			"Chaos-killed pod %s (level %d) to exercise elastic rescaling", pod.Name, ChaosKillLevel)
+		if err := r.DeletePod(job, pod); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,54 @@
+package controllers
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func podsNamed(names ...string) []*corev1.Pod {
+	pods := make([]*corev1.Pod, 0, len(names))
+	for _, name := range names {
+		pods = append(pods, &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name}})
+	}
+	return pods
+}
+
+func TestSelectChaosVictimsDisabled(t *testing.T) {
+	RegisterFailHandler(Fail)
+
+	ChaosKillLevel = ChaosLevelDisabled
+	job := newTestJob(2, 4, 4, "", "")
+	victims, err := selectChaosVictims(job, podsNamed("p0", "p1", "p2", "p3"))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(victims).To(BeEmpty())
+}
+
+func TestSelectChaosVictimsLowLevelKillsOne(t *testing.T) {
+	RegisterFailHandler(Fail)
+	defer func() { ChaosKillLevel = ChaosLevelDisabled }()
+
+	ChaosKillLevel = ChaosLevelLow
+	job := newTestJob(2, 4, 4, "", "")
+	victims, err := selectChaosVictims(job, podsNamed("p0", "p1", "p2", "p3"))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(victims).To(HaveLen(1))
+}
+
+func TestSelectChaosVictimsNeverDropsBelowMinReplicas(t *testing.T) {
+	RegisterFailHandler(Fail)
+	defer func() { ChaosKillLevel = ChaosLevelDisabled }()
+
+	ChaosKillLevel = ChaosLevelHigh
+	job := newTestJob(2, 4, 4, "", "")
+	victims, err := selectChaosVictims(job, podsNamed("p0", "p1"))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(victims).To(BeEmpty())
+
+	victims, err = selectChaosVictims(job, podsNamed("p0", "p1", "p2", "p3"))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(victims).To(HaveLen(2))
+}
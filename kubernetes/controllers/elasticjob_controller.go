@@ -0,0 +1,228 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	commonv1 "github.com/kubeflow/common/pkg/apis/common/v1"
+	"github.com/kubeflow/common/pkg/controller.v1/common"
+	"github.com/kubeflow/common/pkg/controller.v1/control"
+	logger "github.com/kubeflow/common/pkg/util"
+	"github.com/pytorch/elastic/kubernetes/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// jobSucceededReason/jobFailedReason are the JobCondition reasons
+	// recorded when isJobSucceeded/isJobFailed decide the job has reached a
+	// terminal state under its SuccessPolicy/FailurePolicy.
+	jobSucceededReason = "ElasticJobSucceeded"
+	jobFailedReason    = "ElasticJobFailed"
+)
+
+// ElasticJobReconciler reconciles a ElasticJob object.
+type ElasticJobReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+
+	jobController common.JobController
+}
+
+// NewElasticJobReconciler builds an ElasticJobReconciler whose jobController
+// is wired to a real kubeflow-common JobController: event recording and pod
+// control go through the manager's client instead of zero-value fields.
+// main.go must use this instead of constructing ElasticJobReconciler
+// directly, since jobController is unexported and would otherwise be left
+// at its zero value.
+func NewElasticJobReconciler(mgr ctrl.Manager, enableGangScheduling bool) (*ElasticJobReconciler, error) {
+	kubeClientSet, err := kubernetes.NewForConfig(mgr.GetConfig())
+	if err != nil {
+		return nil, fmt.Errorf("building kube client set: %w", err)
+	}
+
+	recorder := mgr.GetEventRecorderFor("elasticjob-controller")
+
+	return &ElasticJobReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+		jobController: common.JobController{
+			Config:         common.JobControllerConfiguration{EnableGangScheduling: enableGangScheduling},
+			KubeClientSet:  kubeClientSet,
+			PodControl:     control.RealPodControl{KubeClient: kubeClientSet, Recorder: recorder},
+			ServiceControl: control.RealServiceControl{KubeClient: kubeClientSet, Recorder: recorder},
+			Recorder:       recorder,
+		},
+	}, nil
+}
+
+// Reconcile drives an ElasticJob towards its desired state. Pod creation,
+// deletion and gang-scheduling lifecycle live in pod.go/podgroup.go; this
+// method fetches the job, lets the shared kubeflow-common job controller
+// reconcile its replicas, applies SuccessPolicy/FailurePolicy to the job
+// status and writes it back, and, when chaos injection is enabled, runs a
+// round of RunChaos and requeues after chaosInterval.
+func (r *ElasticJobReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	job := &v1alpha1.ElasticJob{}
+	if err := r.Get(ctx, req.NamespacedName, job); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	if err := r.jobController.ReconcileJobs(job, job.Spec.ReplicaSpecs, job.Status.JobStatus, &job.Spec.RunPolicy); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if err := r.applyTerminalPolicies(job); err != nil {
+		return ctrl.Result{}, err
+	}
+	if err := r.Status().Update(ctx, job); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if ChaosKillLevel != ChaosLevelDisabled {
+		if err := r.RunChaos(job); err != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{RequeueAfter: chaosInterval}, nil
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager, watching
+// ElasticJobs and the pods they own.
+func (r *ElasticJobReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha1.ElasticJob{}).
+		Owns(&corev1.Pod{}).
+		Complete(r)
+}
+
+// computeDesiredReplicas returns the number of worker replicas the job
+// should currently be running, taken from the worker ReplicaSpec.
+func computeDesiredReplicas(job *v1alpha1.ElasticJob) (int32, error) {
+	workerSpec, ok := job.Spec.ReplicaSpecs[v1alpha1.ElasticReplicaTypeWorker]
+	if !ok || workerSpec == nil {
+		return 0, fmt.Errorf("job %s/%s has no %s replica spec", job.Namespace, job.Name, v1alpha1.ElasticReplicaTypeWorker)
+	}
+
+	if workerSpec.Replicas == nil {
+		return 1, nil
+	}
+
+	return *workerSpec.Replicas, nil
+}
+
+// minReplicasFor returns job.Spec.MinReplicas, defaulting to the desired
+// replica count when unset, matching SetClusterSpecForPod's own defaulting.
+func minReplicasFor(job *v1alpha1.ElasticJob) (int32, error) {
+	if job.Spec.MinReplicas != nil {
+		return *job.Spec.MinReplicas, nil
+	}
+	return computeDesiredReplicas(job)
+}
+
+// maxReplicasFor returns job.Spec.MaxReplicas, defaulting to the desired
+// replica count when unset, matching SetClusterSpecForPod's own defaulting.
+func maxReplicasFor(job *v1alpha1.ElasticJob) (int32, error) {
+	if job.Spec.MaxReplicas != nil {
+		return *job.Spec.MaxReplicas, nil
+	}
+	return computeDesiredReplicas(job)
+}
+
+// replicaCounts returns the succeeded/running/failed worker replica counts
+// recorded in job.Status by the preceding ReconcileJobs call.
+func replicaCounts(job *v1alpha1.ElasticJob) (succeeded, running, failed int32) {
+	workerStatus, ok := job.Status.ReplicaStatuses[v1alpha1.ElasticReplicaTypeWorker]
+	if !ok || workerStatus == nil {
+		return 0, 0, 0
+	}
+	return workerStatus.Succeeded, workerStatus.Active, workerStatus.Failed
+}
+
+// applyTerminalPolicies checks the job's current worker replica counts
+// against its SuccessPolicy/FailurePolicy and records a terminal
+// JobCondition when either is satisfied. ReconcileJobs only knows
+// kubeflow-common's default all-replicas semantics, so this is what
+// actually gives SuccessPolicy/FailurePolicy effect on job status.
+func (r *ElasticJobReconciler) applyTerminalPolicies(job *v1alpha1.ElasticJob) error {
+	succeeded, running, failed := replicaCounts(job)
+
+	failedJob, err := isJobFailed(job, failed)
+	if err != nil {
+		return err
+	}
+	if failedJob {
+		return logger.UpdateJobConditions(&job.Status.JobStatus, commonv1.JobFailed, jobFailedReason,
+			"Job failed: failed replica count exceeded what FailurePolicy tolerates")
+	}
+
+	succeededJob, err := isJobSucceeded(job, succeeded, running, failed)
+	if err != nil {
+		return err
+	}
+	if succeededJob {
+		return logger.UpdateJobConditions(&job.Status.JobStatus, commonv1.JobSucceeded, jobSucceededReason,
+			"Job succeeded: succeeded/running replica counts satisfy SuccessPolicy")
+	}
+
+	return nil
+}
+
+// isJobSucceeded reports whether the given counts of succeeded/running/
+// failed worker replicas satisfy the job's SuccessPolicy. Elastic jobs can
+// legitimately finish with fewer pods than desiredReplicas, so
+// SuccessPolicyAny only requires MinReplicas successes and tolerates
+// failures among the shed replicas; SuccessPolicyDefault/AllWorkers
+// requires every replica to have succeeded, so any failure rules it out.
+func isJobSucceeded(job *v1alpha1.ElasticJob, succeeded, running, failed int32) (bool, error) {
+	switch job.Spec.SuccessPolicy {
+	case v1alpha1.SuccessPolicyAny:
+		minReplicas, err := minReplicasFor(job)
+		if err != nil {
+			return false, err
+		}
+		return succeeded >= minReplicas, nil
+	case v1alpha1.SuccessPolicyDefault, v1alpha1.SuccessPolicyAllWorkers:
+		return failed == 0 && running == 0 && succeeded > 0, nil
+	default:
+		return false, fmt.Errorf("unknown success policy %q", job.Spec.SuccessPolicy)
+	}
+}
+
+// isJobFailed reports whether the number of failed worker replicas exceeds
+// what the job's FailurePolicy tolerates.
+func isJobFailed(job *v1alpha1.ElasticJob, failed int32) (bool, error) {
+	switch job.Spec.FailurePolicy {
+	case v1alpha1.FailurePolicyDefault, v1alpha1.FailurePolicyFailFast:
+		return failed > 0, nil
+	case v1alpha1.FailurePolicyTolerateMaxReplicas:
+		minReplicas, err := minReplicasFor(job)
+		if err != nil {
+			return false, err
+		}
+		maxReplicas, err := maxReplicasFor(job)
+		if err != nil {
+			return false, err
+		}
+		return failed > maxReplicas-minReplicas, nil
+	default:
+		return false, fmt.Errorf("unknown failure policy %q", job.Spec.FailurePolicy)
+	}
+}
@@ -0,0 +1,103 @@
+package controllers
+
+import (
+	"testing"
+
+	commonv1 "github.com/kubeflow/common/pkg/apis/common/v1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pytorch/elastic/kubernetes/api/v1alpha1"
+)
+
+func int32Ptr(i int32) *int32 { return &i }
+
+func newTestJob(minReplicas, maxReplicas, desiredReplicas int32, successPolicy v1alpha1.SuccessPolicy, failurePolicy v1alpha1.FailurePolicy) *v1alpha1.ElasticJob {
+	return &v1alpha1.ElasticJob{
+		Spec: v1alpha1.ElasticJobSpec{
+			MinReplicas:   int32Ptr(minReplicas),
+			MaxReplicas:   int32Ptr(maxReplicas),
+			SuccessPolicy: successPolicy,
+			FailurePolicy: failurePolicy,
+			ReplicaSpecs: map[commonv1.ReplicaType]*commonv1.ReplicaSpec{
+				v1alpha1.ElasticReplicaTypeWorker: {
+					Replicas: int32Ptr(desiredReplicas),
+				},
+			},
+		},
+	}
+}
+
+func TestIsJobSucceeded(t *testing.T) {
+	RegisterFailHandler(Fail)
+
+	// SuccessPolicyDefault requires every worker to succeed.
+	job := newTestJob(2, 4, 4, v1alpha1.SuccessPolicyDefault, v1alpha1.FailurePolicyDefault)
+	succeeded, err := isJobSucceeded(job, 3, 1, 0)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(succeeded).To(BeFalse())
+
+	succeeded, err = isJobSucceeded(job, 4, 0, 0)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(succeeded).To(BeTrue())
+
+	// SuccessPolicyDefault must not report success once any replica has
+	// failed, even when nothing is still running -- this is the case
+	// FailurePolicyTolerateMaxReplicas legitimately lets through as "not
+	// failed" (2 failures tolerated out of maxReplicas(4)-minReplicas(2)=2).
+	job = newTestJob(2, 4, 4, v1alpha1.SuccessPolicyDefault, v1alpha1.FailurePolicyTolerateMaxReplicas)
+	isFailed, err := isJobFailed(job, 2)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(isFailed).To(BeFalse())
+
+	succeeded, err = isJobSucceeded(job, 2, 0, 2)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(succeeded).To(BeFalse())
+
+	// SuccessPolicyAny only requires MinReplicas successes, and tolerates
+	// failures among the replicas it shed.
+	job = newTestJob(2, 4, 4, v1alpha1.SuccessPolicyAny, v1alpha1.FailurePolicyDefault)
+	succeeded, err = isJobSucceeded(job, 1, 1, 0)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(succeeded).To(BeFalse())
+
+	succeeded, err = isJobSucceeded(job, 2, 2, 0)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(succeeded).To(BeTrue())
+
+	succeeded, err = isJobSucceeded(job, 2, 0, 2)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(succeeded).To(BeTrue())
+}
+
+func TestIsJobFailed(t *testing.T) {
+	RegisterFailHandler(Fail)
+
+	// FailurePolicyDefault fails fast on the first failed pod.
+	job := newTestJob(2, 4, 4, v1alpha1.SuccessPolicyDefault, v1alpha1.FailurePolicyDefault)
+	failed, err := isJobFailed(job, 1)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(failed).To(BeTrue())
+
+	// FailurePolicyTolerateMaxReplicas tolerates MaxReplicas-MinReplicas
+	// failures before giving up.
+	job = newTestJob(2, 4, 4, v1alpha1.SuccessPolicyDefault, v1alpha1.FailurePolicyTolerateMaxReplicas)
+	failed, err = isJobFailed(job, 2)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(failed).To(BeFalse())
+
+	failed, err = isJobFailed(job, 3)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(failed).To(BeTrue())
+
+	// Tolerance is MaxReplicas-MinReplicas, not desiredReplicas-MinReplicas:
+	// with maxReplicas(8) > desiredReplicas(4), the job should tolerate more
+	// failures than desiredReplicas-minReplicas would allow.
+	job = newTestJob(2, 8, 4, v1alpha1.SuccessPolicyDefault, v1alpha1.FailurePolicyTolerateMaxReplicas)
+	failed, err = isJobFailed(job, 3)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(failed).To(BeFalse())
+
+	failed, err = isJobFailed(job, 7)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(failed).To(BeTrue())
+}
@@ -32,6 +32,17 @@ func (r *ElasticJobReconciler) CreatePod(job interface{}, pod *corev1.Pod) error
 	log := logger.LoggerForJob(elasticJob)
 	log.Infof("Creating pod %s/%s, Job name: %s.", pod.Namespace, pod.Name, elasticJob.GetName())
 
+	if err := r.ReconcilePodGroup(elasticJob); err != nil {
+		log.Infof("Error reconciling PodGroup for gang scheduling: %s", err.Error())
+		return err
+	}
+	applyGangSchedulingAnnotations(elasticJob, pod)
+
+	if err := r.EnsureRendezvousInfra(elasticJob); err != nil {
+		log.Infof("Error provisioning rendezvous infra: %s", err.Error())
+		return err
+	}
+
 	if err := r.Create(context.Background(), pod); err != nil {
 		log.Infof("Error building a pod via Elastic operator: %s", err.Error())
 		return err
@@ -111,13 +122,26 @@ func ModifyVolumeMount(podTemplate *corev1.PodTemplateSpec, index string) {
 	}
 }
 
+// detectLaunchType scans container's args for a recognized launcher token
+// (see v1alpha1.RecognizedLaunchers) and returns the LaunchType it implies,
+// defaulting to LaunchTypeLaunch when none is found.
+func detectLaunchType(container *corev1.Container) v1alpha1.LaunchType {
+	for _, arg := range container.Args {
+		if launchType, ok := v1alpha1.RecognizedLaunchers[arg]; ok {
+			return launchType
+		}
+	}
+	return v1alpha1.LaunchTypeLaunch
+}
+
 func InsertTorchArgs(container *corev1.Container, torchArgs []string) {
 	insertIndex := -1
 
-	// Traverse the args from the back to find the distributed arg.
-	// If none found, then we assume it's in the command and insert from arg 0.
+	// Traverse the args from the back to find one of the recognized
+	// launcher tokens. If none found, then we assume it's in the command
+	// and insert from arg 0.
 	for i := len(container.Args) - 1; i >= 0; i-- {
-		if container.Args[i] == "torchelastic.distributed.launch" {
+		if _, ok := v1alpha1.RecognizedLaunchers[container.Args[i]]; ok {
 			insertIndex = i
 			break
 		}
@@ -130,6 +154,41 @@ func InsertTorchArgs(container *corev1.Container, torchArgs []string) {
 	container.Args = append(container.Args[:insertIndex], append(torchArgs, container.Args[insertIndex:]...)...)
 }
 
+// launchArgsFor builds the rendezvous launch args in the flag style the
+// given LaunchType expects. torchrun switched to hyphenated `--rdzv-*`
+// flags; the legacy launch/run entrypoints use underscores.
+func launchArgsFor(launchType v1alpha1.LaunchType, rdzvBackend, rdzvEndpoint, rdzvID string, minReplicas, maxReplicas int32) []string {
+	nnodes := strconv.Itoa(int(minReplicas)) + ":" + strconv.Itoa(int(maxReplicas))
+
+	if launchType == v1alpha1.LaunchTypeTorchrun {
+		return []string{
+			"--rdzv-backend=" + rdzvBackend,
+			"--rdzv-endpoint=" + rdzvEndpoint,
+			"--rdzv-id=" + rdzvID,
+			"--nnodes=" + nnodes,
+		}
+	}
+
+	return []string{
+		"--rdzv_backend=" + rdzvBackend,
+		"--rdzv_endpoint=" + rdzvEndpoint,
+		"--rdzv_id=" + rdzvID,
+		"--nnodes=" + nnodes,
+	}
+}
+
+// applyC10dDNSIdentity gives pod the stable per-Pod DNS identity c10d
+// rendezvous depends on: Kubernetes only serves a Pod's
+// "<hostname>.<subdomain>.<ns>.svc.cluster.local" record when both
+// Hostname and Subdomain are set, and Subdomain must name a headless
+// Service (ensureHeadlessService/EnsureRendezvousInfra) that selects the
+// pod. rank0PodDNSName/rendezvousEndpointFor assume exactly this identity
+// for the "<job>-0" pod, so every c10d pod needs it, not just rank 0.
+func applyC10dDNSIdentity(job *v1alpha1.ElasticJob, podTemplate *corev1.PodTemplateSpec, index string) {
+	podTemplate.Spec.Hostname = fmt.Sprintf("%s-%s", job.Name, index)
+	podTemplate.Spec.Subdomain = headlessServiceName(job)
+}
+
 // Set pod environment set for ElasticJob
 func SetClusterSpecForPod(job interface{}, podTemplate *corev1.PodTemplateSpec, index string) error {
 	elasticJob, ok := job.(*v1alpha1.ElasticJob)
@@ -156,16 +215,25 @@ func SetClusterSpecForPod(job interface{}, podTemplate *corev1.PodTemplateSpec,
 		maxReplicas = desiredReplicas
 	}
 
-	launchDefaultArgs := []string{
-		"--rdzv_backend=etcd",
-		"--rdzv_endpoint=" + elasticJob.Spec.RdzvEndpoint,
-		"--rdzv_id=" + elasticJob.Name,
-		"--nnodes=" + strconv.Itoa(int(minReplicas)) + ":" + strconv.Itoa(int(maxReplicas))}
-
 	// Only modify the first container as we assume that's the actually pytorch container
 	// The rest will be side cars that we shouldn't inject.
 	container := &podTemplate.Spec.Containers[0]
 
+	launchType := elasticJob.Spec.LaunchType
+	if launchType == v1alpha1.LaunchTypeUnspecified {
+		launchType = detectLaunchType(container)
+	}
+
+	rendezvous := rendezvousSpecFor(elasticJob)
+	rdzvBackend := rendezvousBackendFlag(rendezvous.Backend)
+	rdzvEndpoint := rendezvousEndpointFor(elasticJob, rendezvous)
+
+	if rendezvous.Backend == v1alpha1.RendezvousBackendC10d {
+		applyC10dDNSIdentity(elasticJob, podTemplate, index)
+	}
+
+	launchDefaultArgs := launchArgsFor(launchType, rdzvBackend, rdzvEndpoint, elasticJob.Name, minReplicas, maxReplicas)
+
 	InsertTorchArgs(container, launchDefaultArgs)
 	ModifyVolumeMount(podTemplate, index)
 
@@ -1,11 +1,15 @@
 package controllers
 
 import (
+	"fmt"
 	"testing"
 
+	commonv1 "github.com/kubeflow/common/pkg/apis/common/v1"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"github.com/pytorch/elastic/kubernetes/api/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestInsertTorchArgs(t *testing.T) {
@@ -25,3 +29,90 @@ func TestInsertTorchArgs(t *testing.T) {
 	Expect(container.Args).To(Equal(
 		[]string{"python", "run.py", "python", "-m", "torchelastic.distributed.launch", "--rdvz", "etcd", "script.py", "--arg1", "val1"}))
 }
+
+func TestInsertTorchArgsRecognizesEachLauncher(t *testing.T) {
+	RegisterFailHandler(Fail)
+
+	torchArgs := []string{"--rdvz", "etcd"}
+	for _, launcherToken := range []string{"torchelastic.distributed.launch", "torch.distributed.run", "torchrun"} {
+		container := corev1.Container{
+			Args: []string{"python", "-m", launcherToken, "script.py"},
+		}
+		InsertTorchArgs(&container, torchArgs)
+		Expect(container.Args).To(Equal(
+			[]string{"python", "-m", launcherToken, "--rdvz", "etcd", "script.py"}), "launcher %s", launcherToken)
+	}
+}
+
+func TestDetectLaunchType(t *testing.T) {
+	RegisterFailHandler(Fail)
+
+	cases := []struct {
+		args     []string
+		expected v1alpha1.LaunchType
+	}{
+		{[]string{"python", "-m", "torchelastic.distributed.launch", "script.py"}, v1alpha1.LaunchTypeLaunch},
+		{[]string{"python", "-m", "torch.distributed.run", "script.py"}, v1alpha1.LaunchTypeRun},
+		{[]string{"torchrun", "script.py"}, v1alpha1.LaunchTypeTorchrun},
+		{[]string{"script.py"}, v1alpha1.LaunchTypeLaunch},
+	}
+
+	for _, c := range cases {
+		container := corev1.Container{Args: c.args}
+		Expect(detectLaunchType(&container)).To(Equal(c.expected))
+	}
+}
+
+// TestSetClusterSpecForPodWiresC10dPodDNS exercises the actual pod-to-Service
+// DNS contract c10d rendezvous depends on: the rank-0 pod's Hostname/
+// Subdomain must combine with its namespace into exactly the FQDN
+// rendezvousEndpointFor hands to torchrun, and Subdomain must name the
+// headless Service EnsureRendezvousInfra provisions -- otherwise the
+// endpoint is NXDOMAIN at runtime even though the string builder tests in
+// rendezvous_test.go pass.
+func TestSetClusterSpecForPodWiresC10dPodDNS(t *testing.T) {
+	RegisterFailHandler(Fail)
+
+	job := &v1alpha1.ElasticJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "job-1", Namespace: "default"},
+		Spec: v1alpha1.ElasticJobSpec{
+			Rendezvous: &v1alpha1.RendezvousSpec{Backend: v1alpha1.RendezvousBackendC10d},
+			ReplicaSpecs: map[commonv1.ReplicaType]*commonv1.ReplicaSpec{
+				v1alpha1.ElasticReplicaTypeWorker: {},
+			},
+		},
+	}
+	podTemplate := &corev1.PodTemplateSpec{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Args: []string{"torchrun", "train.py"}}},
+		},
+	}
+
+	Expect(SetClusterSpecForPod(job, podTemplate, "0")).NotTo(HaveOccurred())
+
+	Expect(podTemplate.Spec.Hostname).To(Equal("job-1-0"))
+	Expect(podTemplate.Spec.Subdomain).To(Equal(headlessServiceName(job)))
+
+	podDNSName := fmt.Sprintf("%s.%s.%s.svc.cluster.local", podTemplate.Spec.Hostname, podTemplate.Spec.Subdomain, job.Namespace)
+	Expect(podDNSName).To(Equal(rank0PodDNSName(job)))
+}
+
+func TestLaunchArgsForUsesHyphensForTorchrun(t *testing.T) {
+	RegisterFailHandler(Fail)
+
+	args := launchArgsFor(v1alpha1.LaunchTypeTorchrun, "etcd", "etcd:2379", "job-1", 2, 4)
+	Expect(args).To(Equal([]string{
+		"--rdzv-backend=etcd",
+		"--rdzv-endpoint=etcd:2379",
+		"--rdzv-id=job-1",
+		"--nnodes=2:4",
+	}))
+
+	args = launchArgsFor(v1alpha1.LaunchTypeLaunch, "etcd", "etcd:2379", "job-1", 2, 4)
+	Expect(args).To(Equal([]string{
+		"--rdzv_backend=etcd",
+		"--rdzv_endpoint=etcd:2379",
+		"--rdzv_id=job-1",
+		"--nnodes=2:4",
+	}))
+}
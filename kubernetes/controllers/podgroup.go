@@ -0,0 +1,106 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	"github.com/pytorch/elastic/kubernetes/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	volcanov1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+)
+
+// PodGroupNameLabel is the label, understood by both volcano and the k8s
+// scheduler-plugins coscheduling plugin, that ties a pod to its PodGroup.
+const PodGroupNameLabel = "scheduling.k8s.io/group-name"
+
+// EnableGangScheduling is a process-wide feature flag so clusters without
+// the volcano PodGroup CRD installed can disable this integration without
+// recompiling. Set from main via --enable-gang-scheduling.
+var EnableGangScheduling = true
+
+// podGroupName returns the deterministic PodGroup name for an ElasticJob.
+func podGroupName(job *v1alpha1.ElasticJob) string {
+	return job.GetName()
+}
+
+// wantsGangScheduling reports whether the job asked for volcano gang
+// scheduling and the integration hasn't been disabled cluster-wide.
+func wantsGangScheduling(job *v1alpha1.ElasticJob) bool {
+	return EnableGangScheduling && job.Spec.SchedulingPolicy != nil &&
+		job.Spec.SchedulingPolicy.Scheduler == v1alpha1.GangSchedulerVolcano
+}
+
+// applyGangSchedulingAnnotations stamps pod with the label/annotations
+// volcano needs to admit it as part of the job's PodGroup.
+func applyGangSchedulingAnnotations(job *v1alpha1.ElasticJob, pod *corev1.Pod) {
+	if !wantsGangScheduling(job) {
+		return
+	}
+
+	if pod.Labels == nil {
+		pod.Labels = map[string]string{}
+	}
+	pod.Labels[PodGroupNameLabel] = podGroupName(job)
+
+	if pod.Annotations == nil {
+		pod.Annotations = map[string]string{}
+	}
+	pod.Annotations[PodGroupNameLabel] = podGroupName(job)
+}
+
+// ReconcilePodGroup creates or updates the volcano PodGroup backing an
+// ElasticJob's gang scheduling. MinMember tracks MinReplicas rather than
+// desiredReplicas so elastic rescaling above the minimum never blocks gang
+// admission.
+func (r *ElasticJobReconciler) ReconcilePodGroup(job *v1alpha1.ElasticJob) error {
+	if !wantsGangScheduling(job) {
+		return nil
+	}
+
+	minReplicas, err := minReplicasFor(job)
+	if err != nil {
+		return err
+	}
+
+	pg := &volcanov1beta1.PodGroup{}
+	err = r.Get(context.Background(), types.NamespacedName{Namespace: job.Namespace, Name: podGroupName(job)}, pg)
+	if apierrors.IsNotFound(err) {
+		pg = &volcanov1beta1.PodGroup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      podGroupName(job),
+				Namespace: job.Namespace,
+			},
+			Spec: volcanov1beta1.PodGroupSpec{
+				MinMember: minReplicas,
+			},
+		}
+		if job.Spec.SchedulingPolicy != nil {
+			pg.Spec.Queue = job.Spec.SchedulingPolicy.Queue
+			pg.Spec.PriorityClassName = job.Spec.SchedulingPolicy.PriorityClassName
+		}
+		if err := controllerutil.SetControllerReference(job, pg, r.Scheme); err != nil {
+			return err
+		}
+		return r.Create(context.Background(), pg)
+	} else if err != nil {
+		return err
+	}
+
+	if pg.Spec.MinMember != minReplicas {
+		pg.Spec.MinMember = minReplicas
+		return r.Update(context.Background(), pg)
+	}
+
+	return nil
+}
@@ -0,0 +1,92 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pytorch/elastic/kubernetes/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	volcanov1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+)
+
+func newGangScheduledJob(name string, minReplicas int32) *v1alpha1.ElasticJob {
+	return &v1alpha1.ElasticJob{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec: v1alpha1.ElasticJobSpec{
+			MinReplicas: int32Ptr(minReplicas),
+			SchedulingPolicy: &v1alpha1.SchedulingPolicy{
+				Scheduler: v1alpha1.GangSchedulerVolcano,
+				Queue:     "default",
+			},
+		},
+	}
+}
+
+func TestApplyGangSchedulingAnnotations(t *testing.T) {
+	RegisterFailHandler(Fail)
+
+	job := newGangScheduledJob("job-1", 2)
+	pod := &corev1.Pod{}
+	applyGangSchedulingAnnotations(job, pod)
+	Expect(pod.Labels[PodGroupNameLabel]).To(Equal("job-1"))
+	Expect(pod.Annotations[PodGroupNameLabel]).To(Equal("job-1"))
+
+	// Jobs that didn't opt into gang scheduling get no annotations.
+	plainJob := &v1alpha1.ElasticJob{ObjectMeta: metav1.ObjectMeta{Name: "job-2", Namespace: "default"}}
+	plainPod := &corev1.Pod{}
+	applyGangSchedulingAnnotations(plainJob, plainPod)
+	Expect(plainPod.Labels).To(BeEmpty())
+	Expect(plainPod.Annotations).To(BeEmpty())
+}
+
+func TestReconcilePodGroupCreatesAndUpdates(t *testing.T) {
+	RegisterFailHandler(Fail)
+
+	scheme := runtime.NewScheme()
+	Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+	Expect(v1alpha1.AddToScheme(scheme)).To(Succeed())
+	Expect(volcanov1beta1.AddToScheme(scheme)).To(Succeed())
+
+	job := newGangScheduledJob("job-1", 2)
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+	r := &ElasticJobReconciler{Client: fakeClient, Scheme: scheme}
+
+	Expect(r.ReconcilePodGroup(job)).To(Succeed())
+
+	pg := &volcanov1beta1.PodGroup{}
+	Expect(r.Get(context.Background(), types.NamespacedName{Namespace: "default", Name: "job-1"}, pg)).To(Succeed())
+	Expect(pg.Spec.MinMember).To(Equal(int32(2)))
+	Expect(pg.Spec.Queue).To(Equal("default"))
+
+	// Rescaling MinReplicas should update MinMember on the existing PodGroup.
+	job.Spec.MinReplicas = int32Ptr(3)
+	Expect(r.ReconcilePodGroup(job)).To(Succeed())
+	Expect(r.Get(context.Background(), types.NamespacedName{Namespace: "default", Name: "job-1"}, pg)).To(Succeed())
+	Expect(pg.Spec.MinMember).To(Equal(int32(3)))
+}
+
+func TestReconcilePodGroupNoopWithoutGangScheduling(t *testing.T) {
+	RegisterFailHandler(Fail)
+
+	scheme := runtime.NewScheme()
+	Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+	Expect(v1alpha1.AddToScheme(scheme)).To(Succeed())
+	Expect(volcanov1beta1.AddToScheme(scheme)).To(Succeed())
+
+	job := &v1alpha1.ElasticJob{ObjectMeta: metav1.ObjectMeta{Name: "job-3", Namespace: "default"}}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+	r := &ElasticJobReconciler{Client: fakeClient, Scheme: scheme}
+
+	Expect(r.ReconcilePodGroup(job)).To(Succeed())
+
+	pg := &volcanov1beta1.PodGroup{}
+	err := r.Get(context.Background(), types.NamespacedName{Namespace: "default", Name: "job-3"}, pg)
+	Expect(err).To(HaveOccurred())
+}
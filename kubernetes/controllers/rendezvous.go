@@ -0,0 +1,183 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pytorch/elastic/kubernetes/api/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// redisPort is the port the controller-provisioned redis sidecar listens
+// on and the port baked into its Service.
+const redisPort = 6379
+
+// headlessServiceName is the headless Service that fronts an ElasticJob's
+// pods for DNS-based rendezvous (c10d).
+func headlessServiceName(job *v1alpha1.ElasticJob) string {
+	return job.GetName() + "-rdzv"
+}
+
+// rank0PodDNSName is the DNS name c10d rendezvous uses to find the stable
+// rank-0 pod through the job's headless Service.
+func rank0PodDNSName(job *v1alpha1.ElasticJob) string {
+	return fmt.Sprintf("%s-0.%s.%s.svc.cluster.local", job.GetName(), headlessServiceName(job), job.GetNamespace())
+}
+
+// redisServiceName names the Deployment and Service backing a
+// controller-provisioned redis rendezvous store.
+func redisServiceName(job *v1alpha1.ElasticJob) string {
+	return job.GetName() + "-redis"
+}
+
+func redisEndpoint(job *v1alpha1.ElasticJob) string {
+	return fmt.Sprintf("%s.%s.svc.cluster.local:%d", redisServiceName(job), job.GetNamespace(), redisPort)
+}
+
+// rendezvousSpecFor returns the job's RendezvousSpec, synthesizing one from
+// the deprecated top-level RdzvEndpoint when Rendezvous is unset so the
+// rest of the controller only has to reason about one shape.
+func rendezvousSpecFor(job *v1alpha1.ElasticJob) *v1alpha1.RendezvousSpec {
+	if job.Spec.Rendezvous != nil {
+		return job.Spec.Rendezvous
+	}
+	return &v1alpha1.RendezvousSpec{Backend: v1alpha1.RendezvousBackendEtcd, Endpoint: job.Spec.RdzvEndpoint}
+}
+
+// rendezvousBackendFlag returns the --rdzv_backend/--rdzv-backend value for
+// the given backend, defaulting to etcd.
+func rendezvousBackendFlag(backend v1alpha1.RendezvousBackend) string {
+	if backend == "" {
+		return string(v1alpha1.RendezvousBackendEtcd)
+	}
+	return string(backend)
+}
+
+// rendezvousEndpointFor resolves the --rdzv_endpoint/--rdzv-endpoint value
+// for the job's configured backend: c10d derives it from the rank-0 pod, a
+// provisioned redis derives it from the sidecar Service, and etcd/etcd-v2
+// and external redis pass the configured endpoint through unchanged.
+func rendezvousEndpointFor(job *v1alpha1.ElasticJob, rendezvous *v1alpha1.RendezvousSpec) string {
+	switch rendezvous.Backend {
+	case v1alpha1.RendezvousBackendC10d:
+		return rank0PodDNSName(job)
+	case v1alpha1.RendezvousBackendRedis:
+		if rendezvous.Provision {
+			return redisEndpoint(job)
+		}
+	}
+	return rendezvous.Endpoint
+}
+
+// EnsureRendezvousInfra provisions whatever cluster-side resources the
+// job's rendezvous backend needs: a headless Service for c10d so pods can
+// resolve rank-0, or a small redis Deployment+Service when the job opted
+// into Rendezvous.Provision. etcd/etcd-v2 and external redis need nothing
+// from the controller.
+func (r *ElasticJobReconciler) EnsureRendezvousInfra(job *v1alpha1.ElasticJob) error {
+	rendezvous := rendezvousSpecFor(job)
+
+	switch rendezvous.Backend {
+	case v1alpha1.RendezvousBackendC10d:
+		return r.ensureHeadlessService(job, headlessServiceName(job))
+	case v1alpha1.RendezvousBackendRedis:
+		if rendezvous.Provision {
+			return r.ensureRedisSidecar(job)
+		}
+	}
+
+	return nil
+}
+
+func (r *ElasticJobReconciler) ensureHeadlessService(job *v1alpha1.ElasticJob, name string) error {
+	svc := &corev1.Service{}
+	err := r.Get(context.Background(), types.NamespacedName{Namespace: job.Namespace, Name: name}, svc)
+	if err == nil {
+		return nil
+	}
+	if !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	svc = &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: job.Namespace},
+		Spec: corev1.ServiceSpec{
+			ClusterIP: corev1.ClusterIPNone,
+			Selector:  r.jobController.GenLabels(job.GetName()),
+		},
+	}
+	if err := controllerutil.SetControllerReference(job, svc, r.Scheme); err != nil {
+		return err
+	}
+	return r.Create(context.Background(), svc)
+}
+
+// ensureRedisSidecar creates a single-replica redis Deployment and the
+// ClusterIP Service in front of it, for jobs that set
+// Rendezvous.Provision instead of pointing at an external redis.
+func (r *ElasticJobReconciler) ensureRedisSidecar(job *v1alpha1.ElasticJob) error {
+	name := redisServiceName(job)
+	labels := map[string]string{"elastic.pytorch.org/redis-for": job.GetName()}
+
+	dep := &appsv1.Deployment{}
+	err := r.Get(context.Background(), types.NamespacedName{Namespace: job.Namespace, Name: name}, dep)
+	if apierrors.IsNotFound(err) {
+		replicas := int32(1)
+		dep = &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: job.Namespace},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: &replicas,
+				Selector: &metav1.LabelSelector{MatchLabels: labels},
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{Labels: labels},
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{{
+							Name:  "redis",
+							Image: "redis:6-alpine",
+							Ports: []corev1.ContainerPort{{ContainerPort: redisPort}},
+						}},
+					},
+				},
+			},
+		}
+		if err := controllerutil.SetControllerReference(job, dep, r.Scheme); err != nil {
+			return err
+		}
+		if err := r.Create(context.Background(), dep); err != nil {
+			return err
+		}
+	} else if err != nil {
+		return err
+	}
+
+	svc := &corev1.Service{}
+	err = r.Get(context.Background(), types.NamespacedName{Namespace: job.Namespace, Name: name}, svc)
+	if apierrors.IsNotFound(err) {
+		svc = &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: job.Namespace},
+			Spec: corev1.ServiceSpec{
+				Selector: labels,
+				Ports:    []corev1.ServicePort{{Port: redisPort, TargetPort: intstr.FromInt(redisPort)}},
+			},
+		}
+		if err := controllerutil.SetControllerReference(job, svc, r.Scheme); err != nil {
+			return err
+		}
+		return r.Create(context.Background(), svc)
+	}
+	return err
+}
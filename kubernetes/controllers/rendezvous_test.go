@@ -0,0 +1,45 @@
+package controllers
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pytorch/elastic/kubernetes/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRendezvousSpecForFallsBackToRdzvEndpoint(t *testing.T) {
+	RegisterFailHandler(Fail)
+
+	job := &v1alpha1.ElasticJob{Spec: v1alpha1.ElasticJobSpec{RdzvEndpoint: "etcd:2379"}}
+	rendezvous := rendezvousSpecFor(job)
+	Expect(rendezvous.Backend).To(Equal(v1alpha1.RendezvousBackendEtcd))
+	Expect(rendezvous.Endpoint).To(Equal("etcd:2379"))
+}
+
+func TestRendezvousEndpointForEachBackend(t *testing.T) {
+	RegisterFailHandler(Fail)
+
+	job := &v1alpha1.ElasticJob{ObjectMeta: metav1.ObjectMeta{Name: "job-1", Namespace: "default"}}
+
+	etcd := &v1alpha1.RendezvousSpec{Backend: v1alpha1.RendezvousBackendEtcd, Endpoint: "etcd:2379"}
+	Expect(rendezvousEndpointFor(job, etcd)).To(Equal("etcd:2379"))
+
+	c10d := &v1alpha1.RendezvousSpec{Backend: v1alpha1.RendezvousBackendC10d}
+	Expect(rendezvousEndpointFor(job, c10d)).To(Equal("job-1-0.job-1-rdzv.default.svc.cluster.local"))
+
+	externalRedis := &v1alpha1.RendezvousSpec{Backend: v1alpha1.RendezvousBackendRedis, Endpoint: "redis:6379"}
+	Expect(rendezvousEndpointFor(job, externalRedis)).To(Equal("redis:6379"))
+
+	provisionedRedis := &v1alpha1.RendezvousSpec{Backend: v1alpha1.RendezvousBackendRedis, Provision: true}
+	Expect(rendezvousEndpointFor(job, provisionedRedis)).To(Equal("job-1-redis.default.svc.cluster.local:6379"))
+}
+
+func TestRendezvousBackendFlagDefaultsToEtcd(t *testing.T) {
+	RegisterFailHandler(Fail)
+
+	Expect(rendezvousBackendFlag("")).To(Equal("etcd"))
+	Expect(rendezvousBackendFlag(v1alpha1.RendezvousBackendC10d)).To(Equal("c10d"))
+	Expect(rendezvousBackendFlag(v1alpha1.RendezvousBackendEtcdV2)).To(Equal("etcd-v2"))
+}
@@ -0,0 +1,92 @@
+/*
+Copyright (c) Facebook, Inc. and its affiliates.
+All rights reserved.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+*/
+
+package main
+
+import (
+	"flag"
+	"os"
+
+	"github.com/pytorch/elastic/kubernetes/api/v1alpha1"
+	"github.com/pytorch/elastic/kubernetes/controllers"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	volcanov1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+)
+
+var (
+	scheme   = runtime.NewScheme()
+	setupLog = ctrl.Log.WithName("setup")
+)
+
+func init() {
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = v1alpha1.AddToScheme(scheme)
+}
+
+func main() {
+	var metricsAddr string
+	var enableLeaderElection bool
+	var enableGangScheduling bool
+	var chaosLevel int
+	flag.StringVar(&metricsAddr, "metrics-addr", ":8080", "The address the metric endpoint binds to.")
+	flag.BoolVar(&enableLeaderElection, "enable-leader-election", false,
+		"Enable leader election for controller manager.")
+	flag.BoolVar(&enableGangScheduling, "enable-gang-scheduling", true,
+		"Enable volcano gang-scheduling support. Disable on clusters where the PodGroup CRD isn't installed.")
+	flag.IntVar(&chaosLevel, "chaos-level", 0,
+		"Chaos-kill worker pods to validate elastic rescaling (0 disabled, 1 kills one worker per interval, 2 kills up to desiredReplicas-MinReplicas). Not for production use.")
+	flag.Parse()
+
+	ctrl.SetLogger(ctrl.Log)
+
+	controllers.EnableGangScheduling = enableGangScheduling
+
+	controllers.ChaosKillLevel = controllers.ChaosLevel(chaosLevel)
+	if controllers.ChaosKillLevel != controllers.ChaosLevelDisabled {
+		setupLog.Info("WARNING: chaos injection is enabled, this is not safe for production", "chaos-level", chaosLevel)
+	}
+	if enableGangScheduling {
+		if err := volcanov1beta1.AddToScheme(scheme); err != nil {
+			setupLog.Error(err, "unable to register volcano scheme")
+			os.Exit(1)
+		}
+	}
+
+	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
+		Scheme:             scheme,
+		MetricsBindAddress: metricsAddr,
+		LeaderElection:     enableLeaderElection,
+	})
+	if err != nil {
+		setupLog.Error(err, "unable to start manager")
+		os.Exit(1)
+	}
+
+	reconciler, err := controllers.NewElasticJobReconciler(mgr, enableGangScheduling)
+	if err != nil {
+		setupLog.Error(err, "unable to build controller", "controller", "ElasticJob")
+		os.Exit(1)
+	}
+	if err := reconciler.SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ElasticJob")
+		os.Exit(1)
+	}
+
+	if err := (&v1alpha1.ElasticJob{}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "ElasticJob")
+		os.Exit(1)
+	}
+
+	setupLog.Info("starting manager")
+	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
+		setupLog.Error(err, "problem running manager")
+		os.Exit(1)
+	}
+}